@@ -0,0 +1,80 @@
+// Package agent lets the model request extra repository context — a
+// directory listing, a file's contents, git history — before committing to
+// an explanation, by exposing a small set of tools it can call.
+package agent
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ToolSpec describes a single tool the model may call.
+type ToolSpec struct {
+	Name        string
+	Description string
+	// Parameters is the tool's arguments as a JSON schema object, e.g.
+	// {"type": "object", "properties": {...}, "required": [...]}.
+	Parameters map[string]interface{}
+	Impl       func(args map[string]interface{}) (string, error)
+}
+
+// ToolCall is a single tool invocation requested by the model.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments map[string]interface{}
+}
+
+// ToolResult is the output of running a ToolCall, to be fed back to the model.
+type ToolResult struct {
+	ToolCallID string
+	Content    string
+}
+
+// stdin is shared across Confirm calls so a piped multi-line input isn't
+// buffered-ahead and discarded by a fresh bufio.Reader on every call.
+var stdin = bufio.NewReader(os.Stdin)
+
+// Confirm asks the user on stdin/stdout whether to run a tool call, since
+// tool execution touches the filesystem and shells out to git. It returns
+// false unless the user explicitly answers "y" or "yes".
+func Confirm(call ToolCall) bool {
+	fmt.Printf("→ run tool %q with args %v? [y/N] ", call.Name, call.Arguments)
+	line, _ := stdin.ReadString('\n')
+	line = strings.TrimSpace(strings.ToLower(line))
+	return line == "y" || line == "yes"
+}
+
+// ExecuteToolCalls runs each call against the matching tool in specs,
+// invoking confirm before each execution. An unknown tool name or a
+// declined confirmation produces an error string as that call's result
+// rather than aborting the rest of the batch.
+func ExecuteToolCalls(calls []ToolCall, specs []ToolSpec, confirm func(ToolCall) bool) []ToolResult {
+	byName := make(map[string]ToolSpec, len(specs))
+	for _, s := range specs {
+		byName[s.Name] = s
+	}
+
+	results := make([]ToolResult, 0, len(calls))
+	for _, call := range calls {
+		spec, ok := byName[call.Name]
+		if !ok {
+			results = append(results, ToolResult{ToolCallID: call.ID, Content: fmt.Sprintf("error: unknown tool %q", call.Name)})
+			continue
+		}
+
+		if confirm != nil && !confirm(call) {
+			results = append(results, ToolResult{ToolCallID: call.ID, Content: "error: user declined to run this tool"})
+			continue
+		}
+
+		out, err := spec.Impl(call.Arguments)
+		if err != nil {
+			out = fmt.Sprintf("error: %v", err)
+		}
+		results = append(results, ToolResult{ToolCallID: call.ID, Content: out})
+	}
+	return results
+}
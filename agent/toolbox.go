@@ -0,0 +1,227 @@
+package agent
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Builtins returns the tool specs available to explain-commit's agent mode.
+func Builtins() []ToolSpec {
+	return []ToolSpec{dirTreeTool, readFileTool, gitLogTool, gitBlameTool, gitDiffRangeTool}
+}
+
+func schema(properties map[string]interface{}, required ...string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+// repoRoot returns the root of the current git repo, so tool paths can be
+// clamped to it before touching the filesystem.
+func repoRoot() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("resolving repo root: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// resolveInRepo joins path onto the repo root and rejects the result if it
+// falls outside the root (an absolute path elsewhere, or a "../" escape),
+// since these tools take untrusted-ish args (the model may be echoing a
+// path it read out of a commit message or diff). It returns both the
+// resolved absolute path and the repo root it was checked against.
+func resolveInRepo(path string) (abs, root string, err error) {
+	root, err = repoRoot()
+	if err != nil {
+		return "", "", err
+	}
+
+	abs = filepath.Join(root, path)
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", "", fmt.Errorf("path %q escapes the repo root", path)
+	}
+	return abs, root, nil
+}
+
+// looksLikeFlag reports whether s would be parsed by git as an option
+// rather than a revision (e.g. "--upload-pack=...") if passed positionally.
+func looksLikeFlag(s string) bool {
+	return strings.HasPrefix(s, "-")
+}
+
+var dirTreeTool = ToolSpec{
+	Name:        "dir_tree",
+	Description: "List files and directories under a path in the repo, recursively.",
+	Parameters: schema(map[string]interface{}{
+		"path": map[string]interface{}{"type": "string", "description": "Directory to list, relative to the repo root. Defaults to \".\"."},
+	}),
+	Impl: dirTree,
+}
+
+func dirTree(args map[string]interface{}) (string, error) {
+	path, _ := args["path"].(string)
+	if path == "" {
+		path = "."
+	}
+	abs, root, err := resolveInRepo(path)
+	if err != nil {
+		return "", fmt.Errorf("dir_tree: %w", err)
+	}
+
+	var b strings.Builder
+	err = filepath.WalkDir(abs, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(&b, rel)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("dir_tree: %w", err)
+	}
+	return b.String(), nil
+}
+
+var readFileTool = ToolSpec{
+	Name:        "read_file",
+	Description: "Read the contents of a file in the repo.",
+	Parameters: schema(map[string]interface{}{
+		"path": map[string]interface{}{"type": "string", "description": "File to read, relative to the repo root."},
+	}, "path"),
+	Impl: readFile,
+}
+
+// maxReadFileBytes caps how much of a file is returned to the model, so a
+// large generated file can't blow the context window.
+const maxReadFileBytes = 64 * 1024
+
+func readFile(args map[string]interface{}) (string, error) {
+	path, _ := args["path"].(string)
+	if path == "" {
+		return "", fmt.Errorf("read_file: path is required")
+	}
+	abs, _, err := resolveInRepo(path)
+	if err != nil {
+		return "", fmt.Errorf("read_file: %w", err)
+	}
+
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return "", fmt.Errorf("read_file: %w", err)
+	}
+	if len(data) > maxReadFileBytes {
+		data = data[:maxReadFileBytes]
+		return string(data) + "\n... (truncated)", nil
+	}
+	return string(data), nil
+}
+
+var gitLogTool = ToolSpec{
+	Name:        "git_log",
+	Description: "Show recent commit history, optionally scoped to a path.",
+	Parameters: schema(map[string]interface{}{
+		"path":  map[string]interface{}{"type": "string", "description": "Limit history to this path. Optional."},
+		"limit": map[string]interface{}{"type": "integer", "description": "Max number of commits to return. Defaults to 20."},
+	}),
+	Impl: gitLog,
+}
+
+func gitLog(args map[string]interface{}) (string, error) {
+	limit := 20
+	if v, ok := args["limit"]; ok {
+		if n, err := toInt(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+
+	gitArgs := []string{"log", "--oneline", "-n", strconv.Itoa(limit)}
+	if path, _ := args["path"].(string); path != "" {
+		gitArgs = append(gitArgs, "--", path)
+	}
+	return runGit(gitArgs...)
+}
+
+var gitBlameTool = ToolSpec{
+	Name:        "git_blame",
+	Description: "Show git blame for a file, attributing each line to its last commit.",
+	Parameters: schema(map[string]interface{}{
+		"path": map[string]interface{}{"type": "string", "description": "File to blame, relative to the repo root."},
+	}, "path"),
+	Impl: gitBlame,
+}
+
+func gitBlame(args map[string]interface{}) (string, error) {
+	path, _ := args["path"].(string)
+	if path == "" {
+		return "", fmt.Errorf("git_blame: path is required")
+	}
+	abs, _, err := resolveInRepo(path)
+	if err != nil {
+		return "", fmt.Errorf("git_blame: %w", err)
+	}
+	return runGit("blame", "--", abs)
+}
+
+var gitDiffRangeTool = ToolSpec{
+	Name:        "git_diff_range",
+	Description: "Show the diff between two revisions.",
+	Parameters: schema(map[string]interface{}{
+		"from": map[string]interface{}{"type": "string", "description": "Base revision, e.g. a commit SHA or HEAD~3."},
+		"to":   map[string]interface{}{"type": "string", "description": "Target revision. Defaults to HEAD."},
+	}, "from"),
+	Impl: gitDiffRange,
+}
+
+func gitDiffRange(args map[string]interface{}) (string, error) {
+	from, _ := args["from"].(string)
+	if from == "" {
+		return "", fmt.Errorf("git_diff_range: from is required")
+	}
+	to, _ := args["to"].(string)
+	if to == "" {
+		to = "HEAD"
+	}
+	if looksLikeFlag(from) || looksLikeFlag(to) {
+		return "", fmt.Errorf("git_diff_range: from/to must be revisions, not flags")
+	}
+	return runGit("diff", fmt.Sprintf("%s..%s", from, to), "--")
+}
+
+func runGit(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}
+
+func toInt(v interface{}) (int, error) {
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case float64:
+		return int(n), nil
+	case string:
+		return strconv.Atoi(n)
+	default:
+		return 0, fmt.Errorf("not a number: %v", v)
+	}
+}
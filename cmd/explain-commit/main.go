@@ -1,46 +1,331 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
+	"github.com/yourname/explain-commit/agent"
+	"github.com/yourname/explain-commit/conversation"
 	"github.com/yourname/explain-commit/internal/git"
 	"github.com/yourname/explain-commit/internal/llm"
 )
 
-func main() {
-	if len(os.Args) > 1 && (os.Args[1] == "--help" || os.Args[1] == "-h") {
-		fmt.Println(`explain-commit - explain the latest Git commit using LM Studio
+const usage = `explain-commit - explain Git commits using an LLM
 
 Usage:
-  explain-commit [--raw]
+  explain-commit [--raw] [--agent]     Explain HEAD and exit
+  explain-commit --rev <sha>           Explain an arbitrary revision
+  explain-commit --range <a>..<b>      Explain a range: per-commit bullets, then a rollup
+  explain-commit --staged              Explain the staged diff (a commit-message drafting aid)
+  explain-commit new [--rev <sha>]      Start a conversation seeded with an explanation of a revision (HEAD by default)
+  explain-commit reply <id> <message>  Continue a conversation
+  explain-commit view <id>             Print a conversation's transcript
+  explain-commit list                  List recent conversations
+  explain-commit rm <id>               Delete a conversation
 
 Options:
-  --raw   Print the raw git show output and exit`)
+  --raw       Print the raw git show output and exit (--rev/HEAD only)
+  --rev       Explain this revision instead of HEAD
+  --range     Explain every commit in a..b, then summarize the range as a whole
+  --staged    Explain `+"`git diff --staged`"+` instead of a commit
+  --output    Output format: text (default), markdown, json, or conventional
+              (a Conventional Commits message suitable for "git commit -F -")
+  --agent     Let the model call tools (dir_tree, read_file, git_log, git_blame,
+              git_diff_range) to inspect the repo before explaining. Prompts for
+              confirmation before each tool call.`
+
+func hasFlag(args []string, name string) bool {
+	for _, arg := range args {
+		if arg == name {
+			return true
+		}
+	}
+	return false
+}
+
+// flagValue returns the value following a "--name value" pair, or from a
+// "--name=value" form.
+func flagValue(args []string, name string) (string, bool) {
+	for i, arg := range args {
+		if arg == name && i+1 < len(args) {
+			return args[i+1], true
+		}
+		if prefix := name + "="; strings.HasPrefix(arg, prefix) {
+			return strings.TrimPrefix(arg, prefix), true
+		}
+	}
+	return "", false
+}
+
+func main() {
+	args := os.Args[1:]
+
+	if hasFlag(args, "--help") || hasFlag(args, "-h") {
+		fmt.Println(usage)
 		return
 	}
 
-	fmt.Println("🔍 Reading latest commit (git show HEAD)...")
-	commitText, err := git.GetLatestCommit()
+	if len(args) > 0 {
+		switch args[0] {
+		case "new":
+			runNew(args[1:])
+			return
+		case "reply":
+			runReply(args[1:])
+			return
+		case "view":
+			runView(args[1:])
+			return
+		case "list":
+			runList()
+			return
+		case "rm":
+			runRemove(args[1:])
+			return
+		}
+	}
+
+	runExplain(args)
+}
+
+// runExplain is the one-shot behavior: explain a commit, range, or the
+// staged diff, and exit.
+func runExplain(args []string) {
+	if rng, ok := flagValue(args, "--range"); ok {
+		runExplainRange(rng)
+		return
+	}
+
+	var commitText string
+	var err error
+	switch {
+	case hasFlag(args, "--staged"):
+		fmt.Println("🔍 Reading staged changes (git diff --staged)...")
+		commitText, err = git.GetStaged()
+	default:
+		rev, ok := flagValue(args, "--rev")
+		if !ok {
+			rev = "HEAD"
+		}
+		fmt.Printf("🔍 Reading commit %s (git show)...\n", rev)
+		commitText, err = git.GetCommit(rev)
+	}
 	if err != nil {
 		log.Fatalf("error: %v", err)
 	}
 
-	if len(os.Args) > 1 && os.Args[1] == "--raw" {
-		fmt.Println("----- RAW COMMIT -----")
+	if hasFlag(args, "--raw") {
+		fmt.Println("----- RAW -----")
 		fmt.Println(commitText)
 		return
 	}
 
-	fmt.Printf("✓ Got commit (%d characters)\n", len(commitText))
+	fmt.Printf("✓ Got %d characters\n", len(commitText))
 
-	fmt.Println("🧠 Asking LM Studio to explain the commit...")
-	explanation, err := llm.ExplainCommit(commitText)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if hasFlag(args, "--agent") {
+		fmt.Println("🧠 Asking the model to explain the commit (agent mode)...")
+		explanation, err := llm.ExplainCommitWithAgent(commitText, agent.Confirm)
+		if err != nil {
+			log.Fatalf("error: %v", err)
+		}
+		fmt.Println("\n📄 Explanation:")
+		fmt.Println(explanation)
+		return
+	}
+
+	output, _ := flagValue(args, "--output")
+	mode := llm.OutputMode(output)
+	if mode == "" {
+		mode = llm.OutputText
+	}
+
+	fmt.Println("🧠 Asking the model to explain the commit...")
+	fmt.Println("\n📄 Explanation:")
+	result, err := llm.ExplainCommitOutputCtx(ctx, commitText, mode)
+	if err != nil {
+		log.Fatalf("\nerror: %v", err)
+	}
+	if mode == llm.OutputJSON {
+		fmt.Println(result)
+	}
+	fmt.Println()
+}
+
+// runExplainRange explains every commit in a..b, then rolls the per-commit
+// summaries up into an overall summary of the range.
+func runExplainRange(rng string) {
+	from, to, ok := strings.Cut(rng, "..")
+	if !ok {
+		log.Fatalf("error: --range wants the form <a>..<b>, got %q", rng)
+	}
+
+	fmt.Printf("🔍 Reading commits in range %s..%s...\n", from, to)
+	shas, err := git.GetRange(from, to)
 	if err != nil {
 		log.Fatalf("error: %v", err)
 	}
 
-	fmt.Println("\n📄 Explanation:")
-	fmt.Println(explanation)
+	commits := make([]llm.CommitInput, 0, len(shas))
+	for _, sha := range shas {
+		text, err := git.GetCommit(sha)
+		if err != nil {
+			log.Fatalf("error: %v", err)
+		}
+		commits = append(commits, llm.CommitInput{SHA: sha, Text: text})
+	}
+
+	fmt.Printf("✓ Got %d commits\n", len(commits))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Println("🧠 Summarizing each commit...")
+	summaries, rollup, err := llm.ExplainRangeCtx(ctx, commits)
+	if err != nil {
+		log.Fatalf("error: %v", err)
+	}
+
+	fmt.Println("\n📄 Per-commit summaries:")
+	for _, s := range summaries {
+		fmt.Printf("- %s: %s\n", shortSHA(s.SHA), s.Summary)
+	}
+
+	fmt.Println("\n📄 Overall summary:")
+	fmt.Println(rollup)
+}
+
+// runNew creates a conversation seeded with an explanation of rev (HEAD by
+// default, or whatever --rev names).
+func runNew(args []string) {
+	rev, ok := flagValue(args, "--rev")
+	if !ok {
+		rev = "HEAD"
+	}
+
+	commitSHA, err := git.GetCommitSHA(rev)
+	if err != nil {
+		log.Fatalf("error: %v", err)
+	}
+	commitText, err := git.GetCommit(rev)
+	if err != nil {
+		log.Fatalf("error: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Println("🧠 Asking the model to explain the commit...")
+	fmt.Println()
+	provider, messages, err := llm.NewSession(ctx, commitText)
+	if err != nil {
+		log.Fatalf("\nerror: %v", err)
+	}
+	fmt.Println()
+
+	session, err := conversation.New(commitSHA, provider.Name(), provider.Model(), messages)
+	if err != nil {
+		log.Fatalf("error: %v", err)
+	}
+
+	fmt.Printf("\nSession %s saved. Continue with: explain-commit reply %s \"<message>\"\n", session.ID, session.ID)
+}
+
+// runReply continues a conversation with a new user message.
+func runReply(args []string) {
+	if len(args) < 2 {
+		log.Fatal("usage: explain-commit reply <id> <message>")
+	}
+	id, message := args[0], strings.Join(args[1:], " ")
+
+	session, err := conversation.Load(id)
+	if err != nil {
+		log.Fatalf("error: %v", err)
+	}
+
+	session.Messages = append(session.Messages, llm.Message{Role: "user", Content: message})
+	if err := session.Save(); err != nil {
+		log.Fatalf("error: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Println()
+	reply, err := llm.ContinueCtx(ctx, session.Provider, session.Model, session.Messages)
+	if err != nil {
+		log.Fatalf("\nerror: %v", err)
+	}
+	fmt.Println()
+
+	session.Messages = append(session.Messages, reply)
+	if err := session.Save(); err != nil {
+		log.Fatalf("error: %v", err)
+	}
+}
+
+// runView prints a conversation's full transcript.
+func runView(args []string) {
+	if len(args) < 1 {
+		log.Fatal("usage: explain-commit view <id>")
+	}
+
+	session, err := conversation.Load(args[0])
+	if err != nil {
+		log.Fatalf("error: %v", err)
+	}
+
+	fmt.Printf("Session %s (commit %s, %s/%s, created %s)\n\n",
+		session.ID, session.CommitSHA, session.Provider, session.Model, session.CreatedAt.Format("2006-01-02 15:04:05"))
+
+	for _, m := range session.Messages {
+		if m.Role == "system" {
+			continue
+		}
+		fmt.Printf("--- %s ---\n%s\n\n", m.Role, m.Content)
+	}
+}
+
+// runList prints recent conversations.
+func runList() {
+	sessions, err := conversation.List()
+	if err != nil {
+		log.Fatalf("error: %v", err)
+	}
+	if len(sessions) == 0 {
+		fmt.Println("No conversations yet. Start one with: explain-commit new")
+		return
+	}
+
+	for _, s := range sessions {
+		fmt.Printf("%s  commit=%s  %s/%s  %s\n",
+			s.ID, s.CommitSHA, s.Provider, s.Model, s.CreatedAt.Format("2006-01-02 15:04:05"))
+	}
+}
+
+// shortSHA returns the first 8 characters of a SHA, or the whole thing if
+// it's shorter than that.
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// runRemove deletes a conversation.
+func runRemove(args []string) {
+	if len(args) < 1 {
+		log.Fatal("usage: explain-commit rm <id>")
+	}
+	if err := conversation.Remove(args[0]); err != nil {
+		log.Fatalf("error: %v", err)
+	}
+	fmt.Printf("Removed session %s\n", args[0])
 }
@@ -0,0 +1,163 @@
+// Package conversation persists chat sessions about a commit to disk, so a
+// one-shot explanation can grow into an iterative Q&A.
+package conversation
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourname/explain-commit/internal/llm"
+)
+
+// idPattern matches the YYYYMMDD-HHMMSS-hex shape produced by newID.
+var idPattern = regexp.MustCompile(`^\d{8}-\d{6}-[0-9a-f]{8}$`)
+
+// Session is a persisted chat about a single commit.
+type Session struct {
+	ID        string        `json:"id"`
+	CommitSHA string        `json:"commit_sha"`
+	Provider  string        `json:"provider"`
+	Model     string        `json:"model"`
+	CreatedAt time.Time     `json:"created_at"`
+	Messages  []llm.Message `json:"messages"`
+}
+
+// dir returns ~/.config/explain-commit/conversations, creating it if needed.
+func dir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("conversation: %w", err)
+	}
+	d := filepath.Join(base, "explain-commit", "conversations")
+	if err := os.MkdirAll(d, 0o755); err != nil {
+		return "", fmt.Errorf("conversation: %w", err)
+	}
+	return d, nil
+}
+
+func path(id string) (string, error) {
+	if !idPattern.MatchString(id) {
+		return "", fmt.Errorf("conversation: invalid session id %q", id)
+	}
+	d, err := dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, id+".json"), nil
+}
+
+// newID returns a sortable, collision-resistant session id.
+func newID() (string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("conversation: %w", err)
+	}
+	return fmt.Sprintf("%s-%s", time.Now().UTC().Format("20060102-150405"), hex.EncodeToString(suffix)), nil
+}
+
+// New creates and persists a session seeded with the given messages.
+func New(commitSHA, provider, model string, messages []llm.Message) (*Session, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Session{
+		ID:        id,
+		CommitSHA: commitSHA,
+		Provider:  provider,
+		Model:     model,
+		CreatedAt: time.Now().UTC(),
+		Messages:  messages,
+	}
+	if err := s.Save(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Load reads a session by id.
+func Load(id string) (*Session, error) {
+	p, err := path(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("conversation: %w", err)
+	}
+
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("conversation: %w", err)
+	}
+	return &s, nil
+}
+
+// Save writes the session to disk, overwriting any previous copy.
+func (s *Session) Save() error {
+	p, err := path(s.ID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("conversation: %w", err)
+	}
+	if err := os.WriteFile(p, data, 0o644); err != nil {
+		return fmt.Errorf("conversation: %w", err)
+	}
+	return nil
+}
+
+// List returns all sessions, most recently created first.
+func List() ([]Session, error) {
+	d, err := dir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(d)
+	if err != nil {
+		return nil, fmt.Errorf("conversation: %w", err)
+	}
+
+	sessions := make([]Session, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		s, err := Load(strings.TrimSuffix(e.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, *s)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+	return sessions, nil
+}
+
+// Remove deletes a session by id.
+func Remove(id string) error {
+	p, err := path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil {
+		return fmt.Errorf("conversation: %w", err)
+	}
+	return nil
+}
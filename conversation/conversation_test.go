@@ -0,0 +1,44 @@
+package conversation
+
+import "testing"
+
+func TestPathRejectsMalformedIDs(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cases := []struct {
+		id    string
+		valid bool
+	}{
+		{"20260726-153000-deadbeef", true},
+		{"../../etc/passwd", false},
+		{"20260726-153000-deadbeef/../../etc/passwd", false},
+		{"not-an-id", false},
+		{"", false},
+	}
+
+	for _, c := range cases {
+		_, err := path(c.id)
+		if c.valid && err != nil {
+			t.Errorf("path(%q): expected no error, got %v", c.id, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("path(%q): expected an error, got none", c.id)
+		}
+	}
+}
+
+func TestLoadRejectsMalformedID(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if _, err := Load("../../etc/passwd"); err == nil {
+		t.Fatal("Load: expected an error for a path-traversal id, got none")
+	}
+}
+
+func TestRemoveRejectsMalformedID(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if err := Remove("../../etc/passwd"); err == nil {
+		t.Fatal("Remove: expected an error for a path-traversal id, got none")
+	}
+}
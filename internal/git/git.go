@@ -9,15 +9,71 @@ import (
 
 // GetLatestCommit returns the full details of the HEAD commit.
 func GetLatestCommit() (string, error) {
-	cmd := exec.Command("git", "show", "--stat", "--patch", "HEAD")
+	return GetCommit("HEAD")
+}
+
+// GetLatestCommitSHA returns the full SHA of the HEAD commit.
+func GetLatestCommitSHA() (string, error) {
+	return GetCommitSHA("HEAD")
+}
+
+// GetCommitSHA returns the full SHA of the given revision.
+func GetCommitSHA(rev string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", rev)
 	cmd.Stderr = os.Stderr
 	out, err := cmd.Output()
 	if err != nil {
-		return "", fmt.Errorf("failed to run git show: %w", err)
+		return "", fmt.Errorf("failed to run git rev-parse %s: %w", rev, err)
+	}
+	sha := strings.TrimSpace(string(out))
+	if sha == "" {
+		return "", fmt.Errorf("empty git rev-parse output for %s", rev)
+	}
+	return sha, nil
+}
+
+// GetCommit returns the full details of the given revision.
+func GetCommit(rev string) (string, error) {
+	cmd := exec.Command("git", "show", "--stat", "--patch", rev)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run git show %s: %w", rev, err)
 	}
 	commitText := strings.TrimSpace(string(out))
 	if commitText == "" {
-		return "", fmt.Errorf("empty git show output")
+		return "", fmt.Errorf("empty git show output for %s", rev)
 	}
 	return commitText, nil
 }
+
+// GetRange returns the SHAs of the commits in (from, to], oldest first.
+func GetRange(from, to string) ([]string, error) {
+	cmd := exec.Command("git", "rev-list", "--reverse", fmt.Sprintf("%s..%s", from, to))
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run git rev-list %s..%s: %w", from, to, err)
+	}
+	trimmed := strings.TrimSpace(string(out))
+	if trimmed == "" {
+		return nil, fmt.Errorf("no commits in range %s..%s", from, to)
+	}
+	return strings.Split(trimmed, "\n"), nil
+}
+
+// GetStaged returns the staged diff (git diff --staged), for use as a
+// commit-message drafting aid before committing.
+func GetStaged() (string, error) {
+	cmd := exec.Command("git", "diff", "--staged")
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run git diff --staged: %w", err)
+	}
+	diffText := strings.TrimSpace(string(out))
+	if diffText == "" {
+		return "", fmt.Errorf("no staged changes")
+	}
+	return diffText, nil
+}
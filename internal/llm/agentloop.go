@@ -0,0 +1,99 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/yourname/explain-commit/agent"
+)
+
+// maxToolRounds bounds the tool-calling loop so a misbehaving model can't
+// keep requesting tools forever.
+const maxToolRounds = 8
+
+// ExplainCommitWithAgent behaves like ExplainCommit but lets the model call
+// agent.Builtins() tools (dir_tree, read_file, git_log, git_blame,
+// git_diff_range) to inspect the repo before producing its final
+// explanation. confirm is invoked before each tool call; it should prompt
+// the user and report whether to proceed (see agent.Confirm).
+func ExplainCommitWithAgent(commitText string, confirm func(agent.ToolCall) bool) (string, error) {
+	provider, err := NewProvider("")
+	if err != nil {
+		return "", err
+	}
+
+	temp := 0.2
+	if tStr := os.Getenv("EXPLAIN_TEMPERATURE"); tStr != "" {
+		if tParsed, err := strconv.ParseFloat(tStr, 64); err == nil {
+			temp = tParsed
+		}
+	}
+
+	userPrompt := fmt.Sprintf(strings.TrimSpace(`
+Here is the latest commit on the current branch:
+
+%s
+
+You may call the available tools to inspect the repository for more context.
+Then explain this commit following the rules.
+`), commitText)
+
+	messages := []Message{
+		{Role: "system", Content: strings.TrimSpace(systemPrompt)},
+		{Role: "user", Content: userPrompt},
+	}
+
+	specs := agent.Builtins()
+	tools := toolDefs(specs)
+
+	for round := 0; round < maxToolRounds; round++ {
+		reply, err := provider.ChatCompletion(messages, temp, tools)
+		if err != nil {
+			return "", err
+		}
+
+		if len(reply.ToolCalls) == 0 {
+			return strings.TrimSpace(reply.Content), nil
+		}
+
+		messages = append(messages, reply)
+
+		results := agent.ExecuteToolCalls(toAgentCalls(reply.ToolCalls), specs, confirm)
+		for _, res := range results {
+			messages = append(messages, Message{Role: "tool", ToolCallID: res.ToolCallID, Content: res.Content})
+		}
+	}
+
+	return "", fmt.Errorf("gave up after %d rounds of tool calls", maxToolRounds)
+}
+
+// toolDefs converts agent tool specs into the wire format providers expect.
+func toolDefs(specs []agent.ToolSpec) []ToolDef {
+	defs := make([]ToolDef, 0, len(specs))
+	for _, s := range specs {
+		defs = append(defs, ToolDef{
+			Type: "function",
+			Function: ToolFunctionDef{
+				Name:        s.Name,
+				Description: s.Description,
+				Parameters:  s.Parameters,
+			},
+		})
+	}
+	return defs
+}
+
+// toAgentCalls parses a provider's raw tool_calls into agent.ToolCall,
+// decoding each call's JSON-encoded arguments.
+func toAgentCalls(raw []ToolCallRaw) []agent.ToolCall {
+	calls := make([]agent.ToolCall, 0, len(raw))
+	for _, r := range raw {
+		var args map[string]interface{}
+		_ = json.Unmarshal([]byte(r.Function.Arguments), &args)
+		calls = append(calls, agent.ToolCall{ID: r.ID, Name: r.Function.Name, Arguments: args})
+	}
+	return calls
+}
@@ -0,0 +1,131 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	anthropicDefaultBaseURL = "https://api.anthropic.com/v1"
+	anthropicDefaultModel   = "claude-sonnet-4-5"
+	anthropicAPIVersion     = "2023-06-01"
+)
+
+type anthropicProvider struct {
+	baseURL string
+	model   string
+	apiKey  string
+}
+
+func newAnthropicProvider(cfg providerConfig) (*anthropicProvider, error) {
+	apiKey := firstNonEmpty(cfg.APIKey, os.Getenv("ANTHROPIC_API_KEY"))
+	if apiKey == "" {
+		return nil, fmt.Errorf("ANTHROPIC_API_KEY is not set")
+	}
+	return &anthropicProvider{
+		baseURL: firstNonEmpty(cfg.BaseURL, os.Getenv("ANTHROPIC_BASE_URL"), anthropicDefaultBaseURL),
+		model:   firstNonEmpty(cfg.Model, os.Getenv("ANTHROPIC_MODEL"), anthropicDefaultModel),
+		apiKey:  apiKey,
+	}, nil
+}
+
+func (p *anthropicProvider) Name() string  { return "anthropic" }
+func (p *anthropicProvider) Model() string { return p.model }
+
+func (p *anthropicProvider) setModel(m string) { p.model = m }
+
+// ChatCompletion talks to Anthropic's /messages endpoint, which takes the
+// system prompt separately from the conversational messages. tools is
+// ignored: Anthropic's tool-use format isn't wired up yet.
+func (p *anthropicProvider) ChatCompletion(messages []Message, temperature float64, tools []ToolDef) (Message, error) {
+	var system string
+	turns := make([]Message, 0, len(messages))
+	for _, m := range messages {
+		if m.Role == "system" {
+			system = strings.TrimSpace(system + "\n" + m.Content)
+			continue
+		}
+		turns = append(turns, m)
+	}
+
+	reqBody := struct {
+		Model       string    `json:"model"`
+		System      string    `json:"system,omitempty"`
+		Messages    []Message `json:"messages"`
+		Temperature float64   `json:"temperature"`
+		MaxTokens   int       `json:"max_tokens"`
+	}{
+		Model:       p.model,
+		System:      system,
+		Messages:    turns,
+		Temperature: temperature,
+		MaxTokens:   1024,
+	}
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	url := strings.TrimRight(p.baseURL, "/") + "/messages"
+
+	httpReq, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", p.apiKey)
+	httpReq.Header.Set("anthropic-version", anthropicAPIVersion)
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return Message{}, fmt.Errorf("API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return Message{}, fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var msgResp struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&msgResp); err != nil {
+		return Message{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	for _, block := range msgResp.Content {
+		if block.Type == "text" && block.Text != "" {
+			return Message{Role: "assistant", Content: strings.TrimSpace(block.Text)}, nil
+		}
+	}
+
+	return Message{}, fmt.Errorf("invalid response: missing text content block")
+}
+
+// ChatCompletionStream falls back to a single non-streaming call: Anthropic's
+// SSE format isn't wired up yet, so onDelta fires once with the full reply.
+// ctx is honored only as a pre-flight check, not mid-request.
+func (p *anthropicProvider) ChatCompletionStream(ctx context.Context, messages []Message, temperature float64, tools []ToolDef, onDelta func(string)) (Message, error) {
+	if err := ctx.Err(); err != nil {
+		return Message{}, err
+	}
+	msg, err := p.ChatCompletion(messages, temperature, tools)
+	if err != nil {
+		return Message{}, err
+	}
+	onDelta(msg.Content)
+	return msg, nil
+}
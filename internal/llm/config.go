@@ -0,0 +1,63 @@
+package llm
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// providerConfig holds the settings a single provider may read from the
+// config file. Any field left empty falls back to that provider's own
+// environment variables and built-in defaults.
+type providerConfig struct {
+	BaseURL string `json:"base_url,omitempty"`
+	APIKey  string `json:"api_key,omitempty"`
+	Model   string `json:"model,omitempty"`
+}
+
+// fileConfig is the on-disk shape of ~/.config/explain-commit/config.json.
+type fileConfig struct {
+	DefaultProvider string         `json:"default_provider,omitempty"`
+	LMStudio        providerConfig `json:"lmstudio,omitempty"`
+	Ollama          providerConfig `json:"ollama,omitempty"`
+	OpenAI          providerConfig `json:"openai,omitempty"`
+	Anthropic       providerConfig `json:"anthropic,omitempty"`
+	Gemini          providerConfig `json:"gemini,omitempty"`
+}
+
+// configPath returns the path to the user's config file.
+func configPath() string {
+	if dir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(dir, "explain-commit", "config.json")
+	}
+	return ""
+}
+
+// loadConfig reads the config file if present. A missing or unreadable file
+// is not an error: it just means every provider falls back to env vars.
+func loadConfig() fileConfig {
+	var cfg fileConfig
+
+	path := configPath()
+	if path == "" {
+		return cfg
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg
+	}
+
+	_ = json.Unmarshal(data, &cfg)
+	return cfg
+}
+
+// firstNonEmpty returns the first non-empty string among vals.
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const systemPrompt = `
+You are a senior software engineer explaining a Git commit to a teammate.
+
+Rules:
+- Give a short high-level summary first (1–3 bullet points).
+- Then describe the main code changes grouped by concern (e.g. "API", "UI", "tests").
+- Explain WHY the changes might have been made (best-effort inference).
+- Keep it concise but clear. No more than about 20 lines total.
+`
+
+// defaultTemperature reads EXPLAIN_TEMPERATURE, falling back to 0.2.
+func defaultTemperature() float64 {
+	temp := 0.2
+	if tStr := os.Getenv("EXPLAIN_TEMPERATURE"); tStr != "" {
+		if tParsed, err := strconv.ParseFloat(tStr, 64); err == nil {
+			temp = tParsed
+		}
+	}
+	return temp
+}
+
+// seedMessages builds the system+user messages sent to explain a commit.
+func seedMessages(commitText string) []Message {
+	userPrompt := fmt.Sprintf(strings.TrimSpace(`
+Here is the latest commit on the current branch:
+
+%s
+
+Explain this commit following the rules.
+`), commitText)
+
+	return []Message{
+		{Role: "system", Content: strings.TrimSpace(systemPrompt)},
+		{Role: "user", Content: userPrompt},
+	}
+}
+
+// ExplainCommit is a thin wrapper around ExplainCommitCtx for callers that
+// don't need cancellation.
+func ExplainCommit(commitText string) (string, error) {
+	return ExplainCommitCtx(context.Background(), commitText)
+}
+
+// ExplainCommitCtx sends the commit text to the configured provider
+// (selected via EXPLAIN_PROVIDER or the explain-commit config file,
+// defaulting to LM Studio), streaming the reply to stdout as tokens arrive.
+// It returns the full explanation once the stream completes, or a wrapped
+// context error if ctx is canceled (e.g. by Ctrl-C) before that happens.
+func ExplainCommitCtx(ctx context.Context, commitText string) (string, error) {
+	provider, err := NewProvider("")
+	if err != nil {
+		return "", err
+	}
+
+	reply, err := provider.ChatCompletionStream(ctx, seedMessages(commitText), defaultTemperature(), nil, printToken)
+	if err != nil {
+		return "", err
+	}
+	return reply.Content, nil
+}
+
+// NewSession seeds a message history the same way ExplainCommitCtx does, but
+// also returns the provider it used and the full history (seed plus reply)
+// so callers such as the conversation subsystem can persist it.
+func NewSession(ctx context.Context, commitText string) (ChatCompletionProvider, []Message, error) {
+	provider, err := NewProvider("")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages := seedMessages(commitText)
+	reply, err := provider.ChatCompletionStream(ctx, messages, defaultTemperature(), nil, printToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return provider, append(messages, reply), nil
+}
+
+// ContinueCtx sends an existing message history (e.g. a saved conversation
+// with a new user reply appended) to the conversation's own provider and
+// model, streaming the reply to stdout, and returns the assistant's message
+// once it completes.
+func ContinueCtx(ctx context.Context, providerName, model string, messages []Message) (Message, error) {
+	provider, err := NewProviderWithModel(providerName, model)
+	if err != nil {
+		return Message{}, err
+	}
+	return provider.ChatCompletionStream(ctx, messages, defaultTemperature(), nil, printToken)
+}
+
+func printToken(token string) {
+	fmt.Print(token)
+}
@@ -0,0 +1,141 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	geminiDefaultBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+	geminiDefaultModel   = "gemini-1.5-flash"
+)
+
+type geminiProvider struct {
+	baseURL string
+	model   string
+	apiKey  string
+}
+
+func newGeminiProvider(cfg providerConfig) (*geminiProvider, error) {
+	apiKey := firstNonEmpty(cfg.APIKey, os.Getenv("GEMINI_API_KEY"))
+	if apiKey == "" {
+		return nil, fmt.Errorf("GEMINI_API_KEY is not set")
+	}
+	return &geminiProvider{
+		baseURL: firstNonEmpty(cfg.BaseURL, os.Getenv("GEMINI_BASE_URL"), geminiDefaultBaseURL),
+		model:   firstNonEmpty(cfg.Model, os.Getenv("GEMINI_MODEL"), geminiDefaultModel),
+		apiKey:  apiKey,
+	}, nil
+}
+
+func (p *geminiProvider) Name() string  { return "gemini" }
+func (p *geminiProvider) Model() string { return p.model }
+
+func (p *geminiProvider) setModel(m string) { p.model = m }
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+// ChatCompletion talks to Gemini's generateContent endpoint, which uses
+// "user"/"model" roles and a separate systemInstruction field. tools is
+// ignored: Gemini's function-calling format isn't wired up yet.
+func (p *geminiProvider) ChatCompletion(messages []Message, temperature float64, tools []ToolDef) (Message, error) {
+	var system string
+	contents := make([]geminiContent, 0, len(messages))
+	for _, m := range messages {
+		if m.Role == "system" {
+			system = strings.TrimSpace(system + "\n" + m.Content)
+			continue
+		}
+		role := "user"
+		if m.Role == "assistant" {
+			role = "model"
+		}
+		contents = append(contents, geminiContent{Role: role, Parts: []geminiPart{{Text: m.Content}}})
+	}
+
+	reqBody := struct {
+		SystemInstruction *geminiContent  `json:"systemInstruction,omitempty"`
+		Contents          []geminiContent `json:"contents"`
+		GenerationConfig  struct {
+			Temperature float64 `json:"temperature"`
+		} `json:"generationConfig"`
+	}{
+		Contents: contents,
+	}
+	if system != "" {
+		reqBody.SystemInstruction = &geminiContent{Parts: []geminiPart{{Text: system}}}
+	}
+	reqBody.GenerationConfig.Temperature = temperature
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	endpoint := fmt.Sprintf("%s/models/%s:generateContent?key=%s",
+		strings.TrimRight(p.baseURL, "/"), p.model, url.QueryEscape(p.apiKey))
+
+	httpReq, err := http.NewRequest("POST", endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return Message{}, fmt.Errorf("API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return Message{}, fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var genResp struct {
+		Candidates []struct {
+			Content geminiContent `json:"content"`
+		} `json:"candidates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&genResp); err != nil {
+		return Message{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(genResp.Candidates) == 0 || len(genResp.Candidates[0].Content.Parts) == 0 {
+		return Message{}, fmt.Errorf("invalid response: missing candidates[0].content.parts")
+	}
+
+	text := strings.TrimSpace(genResp.Candidates[0].Content.Parts[0].Text)
+	return Message{Role: "assistant", Content: text}, nil
+}
+
+// ChatCompletionStream falls back to a single non-streaming call: Gemini's
+// streaming format isn't wired up yet, so onDelta fires once with the full
+// reply. ctx is honored only as a pre-flight check, not mid-request.
+func (p *geminiProvider) ChatCompletionStream(ctx context.Context, messages []Message, temperature float64, tools []ToolDef, onDelta func(string)) (Message, error) {
+	if err := ctx.Err(); err != nil {
+		return Message{}, err
+	}
+	msg, err := p.ChatCompletion(messages, temperature, tools)
+	if err != nil {
+		return Message{}, err
+	}
+	onDelta(msg.Content)
+	return msg, nil
+}
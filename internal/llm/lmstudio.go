@@ -9,40 +9,39 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
-	"strconv"
 	"strings"
 	"time"
 )
 
 const (
-	defaultBaseURL = "http://localhost:1234/v1"
-	defaultModel   = "qwen/qwen3-4b-2507"
-	defaultAPIKey  = "lm-studio"
+	lmStudioDefaultBaseURL = "http://localhost:1234/v1"
+	lmStudioDefaultModel   = "qwen/qwen3-4b-2507"
+	lmStudioDefaultAPIKey  = "lm-studio"
 )
 
-type chatMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+type lmStudioProvider struct {
+	baseURL string
+	model   string
+	apiKey  string
 }
 
-type chatRequest struct {
-	Model       string        `json:"model"`
-	Messages    []chatMessage `json:"messages"`
-	Temperature float64       `json:"temperature"`
+func newLMStudioProvider(cfg providerConfig) *lmStudioProvider {
+	return &lmStudioProvider{
+		baseURL: firstNonEmpty(cfg.BaseURL, os.Getenv("LMSTUDIO_BASE_URL"), lmStudioDefaultBaseURL),
+		model:   firstNonEmpty(cfg.Model, os.Getenv("LMSTUDIO_MODEL"), lmStudioDefaultModel),
+		apiKey:  firstNonEmpty(cfg.APIKey, os.Getenv("LMSTUDIO_API_KEY"), lmStudioDefaultAPIKey),
+	}
 }
 
-type chatChoice struct {
-	Message chatMessage `json:"message"`
-}
+func (p *lmStudioProvider) Name() string  { return "lmstudio" }
+func (p *lmStudioProvider) Model() string { return p.model }
 
-type chatResponse struct {
-	Choices []chatChoice `json:"choices"`
-}
+func (p *lmStudioProvider) setModel(m string) { p.model = m }
 
 // IsRunning checks whether the LM Studio API is reachable.
 func IsRunning(baseURL string) bool {
 	if baseURL == "" {
-		baseURL = defaultBaseURL
+		baseURL = lmStudioDefaultBaseURL
 	}
 	client := &http.Client{Timeout: 5 * time.Second}
 	endpoint := strings.TrimRight(baseURL, "/") + "/models"
@@ -115,13 +114,13 @@ func waitForServer(baseURL string, timeout time.Duration) error {
 
 // StartLMStudio ensures the LM Studio server is running and the model is loaded.
 // It prefers the headless `lms` CLI over starting the GUI application.
-func StartLMStudio() error {
-	if IsRunning(defaultBaseURL) {
+func (p *lmStudioProvider) StartLMStudio() error {
+	if IsRunning(p.baseURL) {
 		// Server is running, check if model is loaded
 		if isLMSCLIAvailable() {
-			if !isModelLoaded(defaultModel) {
+			if !isModelLoaded(p.model) {
 				fmt.Println("Model is not loaded")
-				if err := loadModel(defaultModel); err != nil {
+				if err := loadModel(p.model); err != nil {
 					return err
 				}
 			}
@@ -136,12 +135,12 @@ func StartLMStudio() error {
 		if err := startServerWithCLI(); err != nil {
 			return err
 		}
-		if err := waitForServer(defaultBaseURL, 30*time.Second); err != nil {
+		if err := waitForServer(p.baseURL, 30*time.Second); err != nil {
 			return err
 		}
 		// Load the model
-		if !isModelLoaded(defaultModel) {
-			if err := loadModel(defaultModel); err != nil {
+		if !isModelLoaded(p.model) {
+			if err := loadModel(p.model); err != nil {
 				return err
 			}
 		}
@@ -151,86 +150,79 @@ func StartLMStudio() error {
 	return fmt.Errorf("LM Studio is not running and `lms` CLI is not available; please install LM Studio CLI or start the server manually")
 }
 
-// ExplainCommit sends the commit text to LM Studio and returns an explanation.
-// If LM Studio is not running, it will attempt to start it automatically.
-func ExplainCommit(commitText string) (string, error) {
-	baseURL := defaultBaseURL
-	model := defaultModel
-	apiKey := defaultAPIKey
-
-	if err := StartLMStudio(); err != nil {
-		return "", err
+// ChatCompletion sends messages to LM Studio and returns the assistant's
+// reply. If LM Studio is not running, it attempts to start it automatically.
+func (p *lmStudioProvider) ChatCompletion(messages []Message, temperature float64, tools []ToolDef) (Message, error) {
+	if err := p.StartLMStudio(); err != nil {
+		return Message{}, err
 	}
 
-	temp := 0.2
-	if tStr := os.Getenv("EXPLAIN_TEMPERATURE"); tStr != "" {
-		if tParsed, err := strconv.ParseFloat(tStr, 64); err == nil {
-			temp = tParsed
-		}
-	}
-
-	systemPrompt := strings.TrimSpace(`
-You are a senior software engineer explaining a Git commit to a teammate.
-
-Rules:
-- Give a short high-level summary first (1â€“3 bullet points).
-- Then describe the main code changes grouped by concern (e.g. "API", "UI", "tests").
-- Explain WHY the changes might have been made (best-effort inference).
-- Keep it concise but clear. No more than about 20 lines total.
-`)
-
-	userPrompt := fmt.Sprintf(strings.TrimSpace(`
-Here is the latest commit on the current branch:
-
-%s
-
-Explain this commit following the rules.
-`), commitText)
-
-	reqBody := chatRequest{
-		Model: model,
-		Messages: []chatMessage{
-			{Role: "system", Content: systemPrompt},
-			{Role: "user", Content: userPrompt},
-		},
-		Temperature: temp,
+	reqBody := struct {
+		Model       string    `json:"model"`
+		Messages    []Message `json:"messages"`
+		Temperature float64   `json:"temperature"`
+		Tools       []ToolDef `json:"tools,omitempty"`
+	}{
+		Model:       p.model,
+		Messages:    messages,
+		Temperature: temperature,
+		Tools:       tools,
 	}
 
 	payload, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return Message{}, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	client := &http.Client{Timeout: 60 * time.Second}
-	url := strings.TrimRight(baseURL, "/") + "/chat/completions"
+	url := strings.TrimRight(p.baseURL, "/") + "/chat/completions"
 
 	httpReq, err := http.NewRequest("POST", url, bytes.NewReader(payload))
 	if err != nil {
-		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+		return Message{}, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
 
 	resp, err := client.Do(httpReq)
 	if err != nil {
-		return "", fmt.Errorf("API request failed: %w", err)
+		return Message{}, fmt.Errorf("API request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(body))
+		return Message{}, fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(body))
 	}
 
-	var chatResp chatResponse
+	var chatResp struct {
+		Choices []struct {
+			Message Message `json:"message"`
+		} `json:"choices"`
+	}
 	if err := json.NewDecoder(resp.Body).Decode(&chatResp); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+		return Message{}, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	if len(chatResp.Choices) == 0 || chatResp.Choices[0].Message.Content == "" {
-		return "", fmt.Errorf("invalid response: missing choices[0].message.content")
+	if len(chatResp.Choices) == 0 {
+		return Message{}, fmt.Errorf("invalid response: missing choices[0]")
 	}
 
-	return strings.TrimSpace(chatResp.Choices[0].Message.Content), nil
+	msg := chatResp.Choices[0].Message
+	if msg.Content == "" && len(msg.ToolCalls) == 0 {
+		return Message{}, fmt.Errorf("invalid response: missing choices[0].message.content")
+	}
+	msg.Content = strings.TrimSpace(msg.Content)
+
+	return msg, nil
+}
+
+// ChatCompletionStream streams the reply from LM Studio's OpenAI-compatible
+// /chat/completions endpoint, flushing tokens to onDelta as they arrive.
+func (p *lmStudioProvider) ChatCompletionStream(ctx context.Context, messages []Message, temperature float64, tools []ToolDef, onDelta func(string)) (Message, error) {
+	if err := p.StartLMStudio(); err != nil {
+		return Message{}, err
+	}
+	return chatCompletionStreamOpenAICompatible(ctx, p.baseURL, p.apiKey, p.model, messages, temperature, tools, onDelta)
 }
@@ -0,0 +1,108 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	ollamaDefaultBaseURL = "http://localhost:11434"
+	ollamaDefaultModel   = "llama3"
+)
+
+type ollamaProvider struct {
+	baseURL string
+	model   string
+}
+
+func newOllamaProvider(cfg providerConfig) *ollamaProvider {
+	return &ollamaProvider{
+		baseURL: firstNonEmpty(cfg.BaseURL, os.Getenv("OLLAMA_BASE_URL"), ollamaDefaultBaseURL),
+		model:   firstNonEmpty(cfg.Model, os.Getenv("OLLAMA_MODEL"), ollamaDefaultModel),
+	}
+}
+
+func (p *ollamaProvider) Name() string  { return "ollama" }
+func (p *ollamaProvider) Model() string { return p.model }
+
+func (p *ollamaProvider) setModel(m string) { p.model = m }
+
+// ChatCompletion talks to Ollama's /api/chat endpoint, which is non-streaming
+// when "stream" is false and returns a single JSON object. tools is ignored:
+// Ollama's tool-calling format isn't wired up yet.
+func (p *ollamaProvider) ChatCompletion(messages []Message, temperature float64, tools []ToolDef) (Message, error) {
+	reqBody := struct {
+		Model    string    `json:"model"`
+		Messages []Message `json:"messages"`
+		Stream   bool      `json:"stream"`
+		Options  struct {
+			Temperature float64 `json:"temperature"`
+		} `json:"options"`
+	}{
+		Model:    p.model,
+		Messages: messages,
+		Stream:   false,
+	}
+	reqBody.Options.Temperature = temperature
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	client := &http.Client{Timeout: 120 * time.Second}
+	url := strings.TrimRight(p.baseURL, "/") + "/api/chat"
+
+	httpReq, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return Message{}, fmt.Errorf("API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return Message{}, fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var chatResp struct {
+		Message Message `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&chatResp); err != nil {
+		return Message{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if chatResp.Message.Content == "" {
+		return Message{}, fmt.Errorf("invalid response: missing message.content")
+	}
+
+	chatResp.Message.Content = strings.TrimSpace(chatResp.Message.Content)
+	return chatResp.Message, nil
+}
+
+// ChatCompletionStream falls back to a single non-streaming call: Ollama's
+// streaming format isn't wired up yet, so onDelta fires once with the full
+// reply. ctx is honored only as a pre-flight check, not mid-request.
+func (p *ollamaProvider) ChatCompletionStream(ctx context.Context, messages []Message, temperature float64, tools []ToolDef, onDelta func(string)) (Message, error) {
+	if err := ctx.Err(); err != nil {
+		return Message{}, err
+	}
+	msg, err := p.ChatCompletion(messages, temperature, tools)
+	if err != nil {
+		return Message{}, err
+	}
+	onDelta(msg.Content)
+	return msg, nil
+}
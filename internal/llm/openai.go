@@ -0,0 +1,109 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	openAIDefaultBaseURL = "https://api.openai.com/v1"
+	openAIDefaultModel   = "gpt-4o-mini"
+)
+
+type openAIProvider struct {
+	baseURL string
+	model   string
+	apiKey  string
+}
+
+func newOpenAIProvider(cfg providerConfig) (*openAIProvider, error) {
+	apiKey := firstNonEmpty(cfg.APIKey, os.Getenv("OPENAI_API_KEY"))
+	if apiKey == "" {
+		return nil, fmt.Errorf("OPENAI_API_KEY is not set")
+	}
+	return &openAIProvider{
+		baseURL: firstNonEmpty(cfg.BaseURL, os.Getenv("OPENAI_BASE_URL"), openAIDefaultBaseURL),
+		model:   firstNonEmpty(cfg.Model, os.Getenv("OPENAI_MODEL"), openAIDefaultModel),
+		apiKey:  apiKey,
+	}, nil
+}
+
+func (p *openAIProvider) Name() string  { return "openai" }
+func (p *openAIProvider) Model() string { return p.model }
+
+func (p *openAIProvider) setModel(m string) { p.model = m }
+
+// ChatCompletion talks to the OpenAI-compatible /chat/completions endpoint.
+func (p *openAIProvider) ChatCompletion(messages []Message, temperature float64, tools []ToolDef) (Message, error) {
+	reqBody := struct {
+		Model       string    `json:"model"`
+		Messages    []Message `json:"messages"`
+		Temperature float64   `json:"temperature"`
+		Tools       []ToolDef `json:"tools,omitempty"`
+	}{
+		Model:       p.model,
+		Messages:    messages,
+		Temperature: temperature,
+		Tools:       tools,
+	}
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	url := strings.TrimRight(p.baseURL, "/") + "/chat/completions"
+
+	httpReq, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return Message{}, fmt.Errorf("API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return Message{}, fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var chatResp struct {
+		Choices []struct {
+			Message Message `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&chatResp); err != nil {
+		return Message{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(chatResp.Choices) == 0 {
+		return Message{}, fmt.Errorf("invalid response: missing choices[0]")
+	}
+
+	msg := chatResp.Choices[0].Message
+	if msg.Content == "" && len(msg.ToolCalls) == 0 {
+		return Message{}, fmt.Errorf("invalid response: missing choices[0].message.content")
+	}
+	msg.Content = strings.TrimSpace(msg.Content)
+
+	return msg, nil
+}
+
+// ChatCompletionStream streams the reply from OpenAI's /chat/completions
+// endpoint, flushing tokens to onDelta as they arrive.
+func (p *openAIProvider) ChatCompletionStream(ctx context.Context, messages []Message, temperature float64, tools []ToolDef, onDelta func(string)) (Message, error) {
+	return chatCompletionStreamOpenAICompatible(ctx, p.baseURL, p.apiKey, p.model, messages, temperature, tools, onDelta)
+}
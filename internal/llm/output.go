@@ -0,0 +1,160 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// OutputMode selects how ExplainCommitOutputCtx formats its reply.
+type OutputMode string
+
+const (
+	OutputText         OutputMode = "text"
+	OutputMarkdown     OutputMode = "markdown"
+	OutputJSON         OutputMode = "json"
+	OutputConventional OutputMode = "conventional"
+)
+
+func (m OutputMode) valid() bool {
+	switch m {
+	case OutputText, OutputMarkdown, OutputJSON, OutputConventional:
+		return true
+	default:
+		return false
+	}
+}
+
+const markdownSystemPrompt = `
+You are a senior software engineer explaining a Git commit to a teammate.
+
+Rules:
+- Format the reply as Markdown: a short summary, then "## Changes" and
+  "## Rationale" sections.
+- Use bullet points under "## Changes", grouped by concern (e.g. API, UI, tests).
+- Keep it concise but clear.
+`
+
+const jsonSystemPrompt = `
+You are a senior software engineer explaining a Git commit to a teammate.
+
+Reply with ONLY a single JSON object matching this shape, no prose and no
+code fences:
+{"summary": ["..."], "changes": [{"area": "...", "detail": "..."}], "rationale": "...", "risk": "low|med|high"}
+
+- "summary" is 1-3 short bullet points.
+- "changes" groups the main edits by concern (e.g. "API", "UI", "tests").
+- "rationale" is your best-effort inference of why the change was made.
+- "risk" is your best-effort assessment of how risky this change is to merge.
+`
+
+const conventionalSystemPrompt = `
+You are a senior software engineer drafting a commit message from a diff.
+
+Reply with a Conventional Commits message, suitable for piping into
+"git commit -F -":
+- A header line: "type(scope): short summary" (types: feat, fix, refactor,
+  test, docs, chore, perf, build, ci; scope is optional).
+- A blank line, then a body explaining what changed and why, if it's not
+  obvious from the header alone.
+- A blank line and a footer only if there's a breaking change or an issue
+  reference to call out.
+No prose outside the commit message itself.
+`
+
+// jsonExplanation is the schema ExplainCommitOutputCtx validates OutputJSON
+// replies against.
+type jsonExplanation struct {
+	Summary []string `json:"summary"`
+	Changes []struct {
+		Area   string `json:"area"`
+		Detail string `json:"detail"`
+	} `json:"changes"`
+	Rationale string `json:"rationale"`
+	Risk      string `json:"risk"`
+}
+
+func isValidJSONExplanation(s string) bool {
+	var v jsonExplanation
+	return json.Unmarshal([]byte(s), &v) == nil
+}
+
+func outputMessages(mode OutputMode, commitText string) []Message {
+	var system string
+	switch mode {
+	case OutputMarkdown:
+		system = markdownSystemPrompt
+	case OutputJSON:
+		system = jsonSystemPrompt
+	case OutputConventional:
+		system = conventionalSystemPrompt
+	default:
+		system = systemPrompt
+	}
+
+	userPrompt := fmt.Sprintf(strings.TrimSpace(`
+Here is the commit to explain:
+
+%s
+
+Follow the rules.
+`), commitText)
+
+	return []Message{
+		{Role: "system", Content: strings.TrimSpace(system)},
+		{Role: "user", Content: userPrompt},
+	}
+}
+
+// ExplainCommitOutputCtx behaves like ExplainCommitCtx but in a specific
+// OutputMode. Text and Markdown and Conventional replies stream to stdout as
+// they arrive, same as ExplainCommitCtx. JSON replies are buffered so they
+// can be validated with encoding/json before being returned; an invalid
+// reply is reprompted once with a stricter instruction before giving up.
+func ExplainCommitOutputCtx(ctx context.Context, commitText string, mode OutputMode) (string, error) {
+	if !mode.valid() {
+		return "", fmt.Errorf("unknown output mode %q", mode)
+	}
+
+	provider, err := NewProvider("")
+	if err != nil {
+		return "", err
+	}
+
+	messages := outputMessages(mode, commitText)
+
+	if mode != OutputJSON {
+		reply, err := provider.ChatCompletionStream(ctx, messages, defaultTemperature(), nil, printToken)
+		if err != nil {
+			return "", err
+		}
+		return reply.Content, nil
+	}
+
+	return explainJSON(ctx, provider, messages)
+}
+
+func explainJSON(ctx context.Context, provider ChatCompletionProvider, messages []Message) (string, error) {
+	reply, err := provider.ChatCompletionStream(ctx, messages, defaultTemperature(), nil, func(string) {})
+	if err != nil {
+		return "", err
+	}
+	if isValidJSONExplanation(reply.Content) {
+		return reply.Content, nil
+	}
+
+	messages = append(messages, reply, Message{
+		Role:    "user",
+		Content: "That was not valid JSON matching the schema. Reply with ONLY the JSON object: no prose, no code fences.",
+	})
+
+	reply, err = provider.ChatCompletionStream(ctx, messages, defaultTemperature(), nil, func(string) {})
+	if err != nil {
+		return "", err
+	}
+	if !isValidJSONExplanation(reply.Content) {
+		return "", fmt.Errorf("model did not return valid JSON after a retry")
+	}
+	return reply.Content, nil
+}
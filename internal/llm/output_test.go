@@ -0,0 +1,64 @@
+package llm
+
+import (
+	"context"
+	"testing"
+)
+
+type fakeJSONProvider struct {
+	replies []string
+	calls   int
+}
+
+func (p *fakeJSONProvider) Name() string  { return "fake" }
+func (p *fakeJSONProvider) Model() string { return "fake-model" }
+
+func (p *fakeJSONProvider) ChatCompletion(messages []Message, temperature float64, tools []ToolDef) (Message, error) {
+	return p.next()
+}
+
+func (p *fakeJSONProvider) ChatCompletionStream(ctx context.Context, messages []Message, temperature float64, tools []ToolDef, onDelta func(string)) (Message, error) {
+	return p.next()
+}
+
+func (p *fakeJSONProvider) next() (Message, error) {
+	reply := p.replies[p.calls]
+	p.calls++
+	return Message{Role: "assistant", Content: reply}, nil
+}
+
+func TestIsValidJSONExplanation(t *testing.T) {
+	valid := `{"summary": ["did a thing"], "changes": [{"area": "api", "detail": "added an endpoint"}], "rationale": "needed it", "risk": "low"}`
+	if !isValidJSONExplanation(valid) {
+		t.Errorf("expected %q to be valid", valid)
+	}
+	if isValidJSONExplanation("not json") {
+		t.Error("expected non-JSON to be rejected")
+	}
+}
+
+func TestExplainJSONRetriesOnInvalidReply(t *testing.T) {
+	provider := &fakeJSONProvider{replies: []string{
+		"not json",
+		`{"summary": ["ok"], "changes": [], "rationale": "r", "risk": "low"}`,
+	}}
+
+	result, err := explainJSON(context.Background(), provider, []Message{{Role: "system", Content: "sys"}})
+	if err != nil {
+		t.Fatalf("explainJSON: unexpected error: %v", err)
+	}
+	if !isValidJSONExplanation(result) {
+		t.Errorf("expected a valid JSON result, got %q", result)
+	}
+	if provider.calls != 2 {
+		t.Errorf("expected 2 calls (initial + retry), got %d", provider.calls)
+	}
+}
+
+func TestExplainJSONFailsAfterRetryStillInvalid(t *testing.T) {
+	provider := &fakeJSONProvider{replies: []string{"not json", "still not json"}}
+
+	if _, err := explainJSON(context.Background(), provider, []Message{{Role: "system", Content: "sys"}}); err == nil {
+		t.Fatal("explainJSON: expected an error after two invalid replies, got none")
+	}
+}
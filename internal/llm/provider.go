@@ -0,0 +1,99 @@
+// Package llm talks to chat-completion backends capable of explaining a Git
+// commit: LM Studio, Ollama, OpenAI, Anthropic and Google Gemini.
+package llm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Message is a single chat message exchanged with a provider. ToolCalls is
+// populated on assistant messages that request tool execution; ToolCallID
+// identifies which call a "tool" role message is answering.
+type Message struct {
+	Role       string        `json:"role"`
+	Content    string        `json:"content"`
+	ToolCalls  []ToolCallRaw `json:"tool_calls,omitempty"`
+	ToolCallID string        `json:"tool_call_id,omitempty"`
+}
+
+// ChatCompletionProvider is implemented by each backend capable of producing
+// a chat completion. Implementations own their own auth, base URL and model
+// configuration, read from provider-specific environment variables (and
+// optionally the config file loaded by loadConfig).
+type ChatCompletionProvider interface {
+	// Name returns the provider's identifier, e.g. "openai".
+	Name() string
+	// Model returns the model this provider is configured to use, e.g.
+	// "gpt-4o-mini".
+	Model() string
+	// ChatCompletion sends messages and returns the assistant's reply. tools
+	// may be nil; providers without tool-calling support are free to ignore it.
+	ChatCompletion(messages []Message, temperature float64, tools []ToolDef) (Message, error)
+	// ChatCompletionStream behaves like ChatCompletion but calls onDelta with
+	// each token of the reply as it arrives, and aborts the in-flight request
+	// if ctx is canceled. Providers without real streaming support may fall
+	// back to a single onDelta call with the full content.
+	ChatCompletionStream(ctx context.Context, messages []Message, temperature float64, tools []ToolDef, onDelta func(string)) (Message, error)
+}
+
+// defaultProviderName is used when neither an explicit name, EXPLAIN_PROVIDER,
+// nor a config file specify one.
+const defaultProviderName = "lmstudio"
+
+// NewProvider returns the ChatCompletionProvider for the given name. If name
+// is empty, it falls back to the EXPLAIN_PROVIDER env var, then the config
+// file's default_provider, then "lmstudio".
+func NewProvider(name string) (ChatCompletionProvider, error) {
+	cfg := loadConfig()
+
+	if name == "" {
+		name = os.Getenv("EXPLAIN_PROVIDER")
+	}
+	if name == "" {
+		name = cfg.DefaultProvider
+	}
+	if name == "" {
+		name = defaultProviderName
+	}
+
+	switch strings.ToLower(name) {
+	case "lmstudio", "lm-studio":
+		return newLMStudioProvider(cfg.LMStudio), nil
+	case "ollama":
+		return newOllamaProvider(cfg.Ollama), nil
+	case "openai":
+		return newOpenAIProvider(cfg.OpenAI)
+	case "anthropic":
+		return newAnthropicProvider(cfg.Anthropic)
+	case "gemini":
+		return newGeminiProvider(cfg.Gemini)
+	default:
+		return nil, fmt.Errorf("unknown provider %q (want lmstudio, ollama, openai, anthropic or gemini)", name)
+	}
+}
+
+// modelSetter is implemented by every provider so NewProviderWithModel can
+// override the model its own config/env would otherwise have picked.
+type modelSetter interface {
+	setModel(model string)
+}
+
+// NewProviderWithModel behaves like NewProvider, but afterward pins the
+// provider to model (e.g. one recorded on a persisted conversation) if model
+// is non-empty, so a reply continues on the same model the conversation
+// started with rather than whatever config/env currently say.
+func NewProviderWithModel(name, model string) (ChatCompletionProvider, error) {
+	provider, err := NewProvider(name)
+	if err != nil {
+		return nil, err
+	}
+	if model != "" {
+		if ms, ok := provider.(modelSetter); ok {
+			ms.setModel(model)
+		}
+	}
+	return provider, nil
+}
@@ -0,0 +1,83 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CommitInput is one commit's full `git show` text, to be summarized as part
+// of a range.
+type CommitInput struct {
+	SHA  string
+	Text string
+}
+
+// CommitSummary is a single commit's bullet-point summary within a range.
+type CommitSummary struct {
+	SHA     string
+	Summary string
+}
+
+const rangeCommitSystemPrompt = `
+You are a senior software engineer summarizing one commit from a larger
+range for a PR-style rollup.
+
+Rules:
+- One or two short bullet points: what changed and why.
+- No preamble, no restating the commit message verbatim.
+`
+
+const rangeRollupSystemPrompt = `
+You are a senior software engineer writing the overall summary for a range
+of commits, given per-commit bullet points already produced by a first pass.
+
+Rules:
+- Open with a short high-level summary of the range as a whole.
+- Group related commits by concern rather than listing them one by one.
+- Call out anything that looks risky or worth a closer look in review.
+- No more than about 30 lines total.
+`
+
+// ExplainRangeCtx summarizes each commit in commits individually (to stay
+// within model context limits on large ranges) and then produces an overall
+// rollup from those per-commit summaries.
+func ExplainRangeCtx(ctx context.Context, commits []CommitInput) ([]CommitSummary, string, error) {
+	provider, err := NewProvider("")
+	if err != nil {
+		return nil, "", err
+	}
+
+	summaries := make([]CommitSummary, 0, len(commits))
+	for _, c := range commits {
+		if err := ctx.Err(); err != nil {
+			return summaries, "", err
+		}
+
+		messages := []Message{
+			{Role: "system", Content: strings.TrimSpace(rangeCommitSystemPrompt)},
+			{Role: "user", Content: c.Text},
+		}
+		reply, err := provider.ChatCompletionStream(ctx, messages, defaultTemperature(), nil, func(string) {})
+		if err != nil {
+			return nil, "", fmt.Errorf("summarizing %s: %w", c.SHA, err)
+		}
+		summaries = append(summaries, CommitSummary{SHA: c.SHA, Summary: reply.Content})
+	}
+
+	var bullets strings.Builder
+	for _, s := range summaries {
+		fmt.Fprintf(&bullets, "%s:\n%s\n\n", s.SHA, s.Summary)
+	}
+
+	rollupMessages := []Message{
+		{Role: "system", Content: strings.TrimSpace(rangeRollupSystemPrompt)},
+		{Role: "user", Content: strings.TrimSpace(bullets.String())},
+	}
+	rollup, err := provider.ChatCompletionStream(ctx, rollupMessages, defaultTemperature(), nil, printToken)
+	if err != nil {
+		return summaries, "", err
+	}
+
+	return summaries, rollup.Content, nil
+}
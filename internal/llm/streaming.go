@@ -0,0 +1,108 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// streamFrame is one SSE "data: {...}" frame from an OpenAI-compatible
+// streaming chat completion.
+type streamFrame struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+// chatCompletionStreamOpenAICompatible streams a chat completion from an
+// OpenAI-compatible /chat/completions endpoint (LM Studio, OpenAI) using
+// stream: true, calling onDelta with each token as it arrives. It stops at
+// a "data: [DONE]" frame or when ctx is canceled.
+func chatCompletionStreamOpenAICompatible(ctx context.Context, baseURL, apiKey, model string, messages []Message, temperature float64, tools []ToolDef, onDelta func(string)) (Message, error) {
+	reqBody := struct {
+		Model       string    `json:"model"`
+		Messages    []Message `json:"messages"`
+		Temperature float64   `json:"temperature"`
+		Tools       []ToolDef `json:"tools,omitempty"`
+		Stream      bool      `json:"stream"`
+	}{
+		Model:       model,
+		Messages:    messages,
+		Temperature: temperature,
+		Tools:       tools,
+		Stream:      true,
+	}
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	client := &http.Client{Timeout: 5 * time.Minute}
+	url := strings.TrimRight(baseURL, "/") + "/chat/completions"
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return Message{}, fmt.Errorf("API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return Message{}, fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var content strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+
+		data := strings.TrimPrefix(line, "data: ")
+		if data == "[DONE]" {
+			break
+		}
+
+		var frame streamFrame
+		if err := json.Unmarshal([]byte(data), &frame); err != nil {
+			continue
+		}
+		if len(frame.Choices) == 0 || frame.Choices[0].Delta.Content == "" {
+			continue
+		}
+
+		token := frame.Choices[0].Delta.Content
+		content.WriteString(token)
+		onDelta(token)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Message{}, fmt.Errorf("failed to read stream: %w", err)
+	}
+
+	text := strings.TrimSpace(content.String())
+	if text == "" {
+		return Message{}, fmt.Errorf("invalid response: empty stream")
+	}
+
+	return Message{Role: "assistant", Content: text}, nil
+}
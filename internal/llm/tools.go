@@ -0,0 +1,26 @@
+package llm
+
+// ToolDef describes a tool a provider may offer to the model, in the
+// OpenAI-compatible function-calling shape that LM Studio and OpenAI share.
+type ToolDef struct {
+	Type     string          `json:"type"`
+	Function ToolFunctionDef `json:"function"`
+}
+
+// ToolFunctionDef is the function body of a ToolDef.
+type ToolFunctionDef struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Parameters  map[string]interface{} `json:"parameters"`
+}
+
+// ToolCallRaw is a single tool invocation as returned on the wire by a
+// provider, with JSON-encoded arguments.
+type ToolCallRaw struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}